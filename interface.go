@@ -3,6 +3,7 @@ package go_session_store
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // SessionStorer defines an interface for the backend of a browser/app session storage system
@@ -15,14 +16,38 @@ type SessionStorer interface {
 	// @return err errors encountered if saving the session or nil if no error occurred. If the generated session conflicted with an existing session, return ErrSessionCollision
 	GenerateAndStore(ctx context.Context, userId string, metaData string) (session []byte, err error)
 
+	// GenerateAndStoreWithTTL behaves like GenerateAndStore but the stored session expires after ttl elapses.
+	// A ttl <= 0 means the session never expires on its own, the same as GenerateAndStore.
+	// @param ctx the context to use for timeouts, if required
+	// @param userId the user that this session will represent
+	// @param metaData is any data you wish to include when the session is looked up again
+	// @param ttl how long the session should remain valid for. Implementations should honor this on Get and purge expired sessions lazily
+	// @return session identifier ideally created from a SessionIdGenerator
+	// @return err errors encountered if saving the session or nil if no error occurred. If the generated session conflicted with an existing session, return ErrSessionCollision
+	GenerateAndStoreWithTTL(ctx context.Context, userId string, metaData string, ttl time.Duration) (session []byte, err error)
+
 	// Get given a session created by GenerateAndStore., return the userId and metaData, if any. userId will be blank string if missing.
 	// No ok sentinel value is used as it makes no sense to use GenerateAndStore without some sort of userId key
 	// @param ctx the context to use for timeouts, if required
 	// @param session the session that was created by GenerateAndStore.
 	// @return userId the user that this session will represent. If no session exists, this will be an empty string
 	// @return metaData is any data you wish to include when the session is looked up again. If no session exists, this will be an empty string
-	// @return err the error encountered when looking up the session. This should NOT be a value representing no session
-	Get(ctx context.Context, session []byte) (userId string, metaData string, err error)
+	// @return expiresAt the time the session will stop being valid, if it was created with a ttl. Zero value if the session does not expire
+	// @return err the error encountered when looking up the session. If the session does not exist or has expired, this should be ErrSessionNotFound
+	Get(ctx context.Context, session []byte) (userId string, metaData string, expiresAt time.Time, err error)
+
+	// Delete removes a session so that it can no longer be looked up by Get. Deleting a session that does not exist is not an error
+	// @param ctx the context to use for timeouts, if required
+	// @param session the session that was created by GenerateAndStore.
+	// @return err errors encountered while removing the session, or nil if the session was removed (or never existed)
+	Delete(ctx context.Context, session []byte) error
+
+	// DeleteAllForUser removes every session belonging to userId. This is intended for "log out of all devices" and administrative revocation flows
+	// @param ctx the context to use for timeouts, if required
+	// @param userId the user whose sessions should be removed
+	// @return deleted the number of sessions that were removed
+	// @return err errors encountered while removing the sessions
+	DeleteAllForUser(ctx context.Context, userId string) (deleted int, err error)
 }
 
 type SessionIdGenerator interface {
@@ -34,6 +59,10 @@ type SessionIdGenerator interface {
 
 var ErrSessionCollision = errors.New("unable to store session, existing session ID already exists")
 
+// ErrSessionNotFound is returned by SessionStorer.Get when the session does not exist or has expired.
+// It is distinct from ErrSessionCollision, which is only ever returned while generating a new session.
+var ErrSessionNotFound = errors.New("session not found or has expired")
+
 // New creates a new session and will attempt to retry it a maxGenerateAttempts time in case session generation is random. This is to prevent collisions from occurring
 // if the SessionIdGenerator will never encounter a collision, this isn't needed, but most will NOT be this way ;). While it's unlikely to have a collision for 128 bit-wide sessions, it's not IMPOSSIBLE, but highly unlikely. In this case, you don't want to over-write the existing session.
 // This method will try to set another session and will try to save it. If maxGenerateAttempts is exhausted, then it will return ErrSessionCollision.
@@ -52,3 +81,21 @@ func New(ctx context.Context, storer SessionStorer, userId string, metaData stri
 	}
 	return []byte{}, ErrSessionCollision
 }
+
+// NewWithTTL behaves like New, but the created session expires after ttl elapses. See GenerateAndStoreWithTTL.
+// @param ctx the context to use for timing out network-based requests, for sessionStores that support it
+// @param storer the session storage into which sessions are saved
+// @param userId the user's identifier to use when looking up sessions to know which user (or whatever) the session is attached to
+// @param metaData arbitrary data you wish to store with the userId.
+// @param ttl how long the session should remain valid for
+// @param maxGenerateAttempts is the maximum number of times to try to generate and save the session Id before giving up. It will only retry if the error was due to a collision and not for other errors.
+func NewWithTTL(ctx context.Context, storer SessionStorer, userId string, metaData string, ttl time.Duration, maxGenerateAttempts int) (session []byte, err error) {
+	for i := 0; i < maxGenerateAttempts; i++ {
+		session, err = storer.GenerateAndStoreWithTTL(ctx, userId, metaData, ttl)
+		if err == ErrSessionCollision {
+			continue
+		}
+		return session, err
+	}
+	return []byte{}, ErrSessionCollision
+}
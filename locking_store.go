@@ -0,0 +1,108 @@
+package go_session_store
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// UpdatableStorer is a SessionStorer that also supports updating the metaData of an
+// already-created session in place, without changing the session Id. LockingStore requires
+// this so that WithSession can commit the result of a read-modify-write.
+type UpdatableStorer interface {
+	SessionStorer
+
+	// Update replaces the metaData stored against session, leaving userId and any TTL/expiry
+	// untouched. Updating a session that does not exist should return ErrSessionNotFound.
+	// @param ctx the context to use for timeouts, if required
+	// @param session the session that was created by GenerateAndStore
+	// @param metaData the new metaData to store against session
+	// @return err errors encountered while updating the session
+	Update(ctx context.Context, session []byte, metaData string) error
+}
+
+// lockStripeCount is the number of mutexes a LockingStore stripes sessions across. Per-session
+// locking without an unbounded map of mutexes; collisions between unrelated sessions just mean
+// they serialize unnecessarily, they never miss a lock that's actually needed.
+const lockStripeCount = 32
+
+// LockingStore decorates an UpdatableStorer so that concurrent Get/Update/WithSession calls
+// against the same session serialize instead of racing, which matters for callers (e.g. HTTP
+// middleware) that read a session, mutate its metaData, and write it back. GenerateAndStore and
+// GenerateAndStoreWithTTL take no lock, so New's collision-retry loop stays lock-free, since a
+// freshly generated session Id cannot yet be contended.
+type LockingStore struct {
+	storer  UpdatableStorer
+	stripes [lockStripeCount]sync.RWMutex
+}
+
+// NewLockingStore wraps storer with per-session locking.
+func NewLockingStore(storer UpdatableStorer) *LockingStore {
+	return &LockingStore{storer: storer}
+}
+
+func (l *LockingStore) stripeFor(session []byte) *sync.RWMutex {
+	h := fnv.New32a()
+	_, _ = h.Write(session)
+	return &l.stripes[h.Sum32()%lockStripeCount]
+}
+
+func (l *LockingStore) GenerateAndStore(ctx context.Context, userId string, metaData string) (session []byte, err error) {
+	return l.storer.GenerateAndStore(ctx, userId, metaData)
+}
+
+func (l *LockingStore) GenerateAndStoreWithTTL(ctx context.Context, userId string, metaData string, ttl time.Duration) (session []byte, err error) {
+	return l.storer.GenerateAndStoreWithTTL(ctx, userId, metaData, ttl)
+}
+
+func (l *LockingStore) Get(ctx context.Context, session []byte) (userId string, metaData string, expiresAt time.Time, err error) {
+	mu := l.stripeFor(session)
+	mu.RLock()
+	defer mu.RUnlock()
+	return l.storer.Get(ctx, session)
+}
+
+func (l *LockingStore) Delete(ctx context.Context, session []byte) error {
+	mu := l.stripeFor(session)
+	mu.Lock()
+	defer mu.Unlock()
+	return l.storer.Delete(ctx, session)
+}
+
+func (l *LockingStore) DeleteAllForUser(ctx context.Context, userId string) (deleted int, err error) {
+	return l.storer.DeleteAllForUser(ctx, userId)
+}
+
+func (l *LockingStore) Update(ctx context.Context, session []byte, metaData string) error {
+	mu := l.stripeFor(session)
+	mu.Lock()
+	defer mu.Unlock()
+	return l.storer.Update(ctx, session, metaData)
+}
+
+// WithSession performs an atomic read-modify-write against session: it locks the session's
+// stripe, reads the current userId/metaData, calls fn, and stores whatever metaData fn
+// returns. The lock is held for the duration of fn, so concurrent Get/Update/WithSession
+// calls against the same session wait for it to finish.
+// @param ctx the context to use for timeouts, if required
+// @param session the session to read and update
+// @param fn receives the session's current userId/metaData and returns the metaData to store back
+// @return err errors encountered reading the session, running fn, or storing its result
+func (l *LockingStore) WithSession(ctx context.Context, session []byte, fn func(userId string, metaData string) (newMetaData string, err error)) (err error) {
+	mu := l.stripeFor(session)
+	mu.Lock()
+	defer mu.Unlock()
+
+	userId, metaData, _, err := l.storer.Get(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	newMetaData, err := fn(userId, metaData)
+	if err != nil {
+		return err
+	}
+
+	return l.storer.Update(ctx, session, newMetaData)
+}
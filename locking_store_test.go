@@ -0,0 +1,51 @@
+package go_session_store_test
+
+import (
+	"context"
+	"crypto/rand"
+	"strconv"
+	"sync"
+	"testing"
+
+	sessions "github.com/wojnosystems/go_session_store"
+	"github.com/wojnosystems/go_session_store/memstore"
+)
+
+func TestLockingStore_WithSession_SerializesConcurrentIncrements(t *testing.T) {
+	ctx := context.Background()
+	store := memstore.New(sessions.NewRandomSource(16, rand.Reader))
+	locking := sessions.NewLockingStore(store)
+
+	session, err := store.GenerateAndStore(ctx, "user-1", "0")
+	if err != nil {
+		t.Fatalf("unexpected error setting up session: %v", err)
+	}
+
+	const increments = 200
+	var wg sync.WaitGroup
+	wg.Add(increments)
+	for i := 0; i < increments; i++ {
+		go func() {
+			defer wg.Done()
+			err := locking.WithSession(ctx, session, func(userId string, metaData string) (string, error) {
+				n, err := strconv.Atoi(metaData)
+				if err != nil {
+					return "", err
+				}
+				return strconv.Itoa(n + 1), nil
+			})
+			if err != nil {
+				t.Errorf("unexpected error from WithSession: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	_, metaData, _, err := locking.Get(ctx, session)
+	if err != nil {
+		t.Fatalf("unexpected error reading back session: %v", err)
+	}
+	if metaData != strconv.Itoa(increments) {
+		t.Fatalf("expected metaData %q after %d serialized increments, got %q", strconv.Itoa(increments), increments, metaData)
+	}
+}
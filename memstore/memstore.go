@@ -0,0 +1,117 @@
+// Package memstore provides an in-memory SessionStorer implementation. It is intended
+// as a reference backend and for use in tests; sessions do not survive process restarts.
+package memstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	sessions "github.com/wojnosystems/go_session_store"
+)
+
+// entry is what is stored per-session.
+type entry struct {
+	userId    string
+	metaData  string
+	expiresAt time.Time // zero value means the session never expires
+}
+
+func (e entry) expired() bool {
+	return !e.expiresAt.IsZero() && time.Now().After(e.expiresAt)
+}
+
+// Store is an in-memory SessionStorer. It is safe for concurrent use.
+type Store struct {
+	generator sessions.SessionIdGenerator
+	mu        sync.Mutex
+	entries   map[string]entry
+}
+
+// New creates an in-memory Store that uses generator to create new session Ids.
+// @param generator the SessionIdGenerator used by GenerateAndStore and GenerateAndStoreWithTTL
+func New(generator sessions.SessionIdGenerator) *Store {
+	return &Store{
+		generator: generator,
+		entries:   make(map[string]entry),
+	}
+}
+
+func (s *Store) GenerateAndStore(ctx context.Context, userId string, metaData string) (session []byte, err error) {
+	return s.GenerateAndStoreWithTTL(ctx, userId, metaData, 0)
+}
+
+func (s *Store) GenerateAndStoreWithTTL(ctx context.Context, userId string, metaData string, ttl time.Duration) (session []byte, err error) {
+	session, err = s.generator.Generate()
+	if err != nil {
+		return nil, err
+	}
+	key := string(session)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.entries[key]; ok && !existing.expired() {
+		return nil, sessions.ErrSessionCollision
+	}
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	s.entries[key] = entry{userId: userId, metaData: metaData, expiresAt: expiresAt}
+	return session, nil
+}
+
+func (s *Store) Get(ctx context.Context, session []byte) (userId string, metaData string, expiresAt time.Time, err error) {
+	key := string(session)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return "", "", time.Time{}, sessions.ErrSessionNotFound
+	}
+	if e.expired() {
+		delete(s.entries, key)
+		return "", "", time.Time{}, sessions.ErrSessionNotFound
+	}
+	return e.userId, e.metaData, e.expiresAt, nil
+}
+
+// Update replaces the metaData stored against session, implementing sessions.UpdatableStorer.
+func (s *Store) Update(ctx context.Context, session []byte, metaData string) error {
+	key := string(session)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok || e.expired() {
+		delete(s.entries, key)
+		return sessions.ErrSessionNotFound
+	}
+	e.metaData = metaData
+	s.entries[key] = e
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, session []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, string(session))
+	return nil
+}
+
+func (s *Store) DeleteAllForUser(ctx context.Context, userId string) (deleted int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, e := range s.entries {
+		if e.userId == userId {
+			delete(s.entries, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,123 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sessions "github.com/wojnosystems/go_session_store"
+)
+
+// fixedGenerator returns the same, pre-determined session Ids in order, so tests can force
+// a collision without depending on a real random source.
+type fixedGenerator struct {
+	sessionIds [][]byte
+	calls      int
+}
+
+func (g *fixedGenerator) Generate() ([]byte, error) {
+	id := g.sessionIds[g.calls%len(g.sessionIds)]
+	g.calls++
+	return id, nil
+}
+
+func TestStore_GenerateAndStoreWithTTL_ExpiresLazilyOnGet(t *testing.T) {
+	ctx := context.Background()
+	store := New(&fixedGenerator{sessionIds: [][]byte{[]byte("session-1")}})
+
+	session, err := store.GenerateAndStoreWithTTL(ctx, "user-1", "meta", time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, _, _, err = store.Get(ctx, session)
+	if !errors.Is(err, sessions.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound for an expired session, got %v", err)
+	}
+}
+
+func TestStore_Get_ReturnsExpiresAt(t *testing.T) {
+	ctx := context.Background()
+	store := New(&fixedGenerator{sessionIds: [][]byte{[]byte("session-1")}})
+
+	session, err := store.GenerateAndStoreWithTTL(ctx, "user-1", "meta", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, _, expiresAt, err := store.Get(ctx, session)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expiresAt.IsZero() {
+		t.Fatal("expected a non-zero expiresAt for a session created with a TTL")
+	}
+}
+
+func TestStore_GenerateAndStore_CollidesWithLiveSession(t *testing.T) {
+	ctx := context.Background()
+	store := New(&fixedGenerator{sessionIds: [][]byte{[]byte("session-1")}})
+
+	if _, err := store.GenerateAndStore(ctx, "user-1", "meta"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := store.GenerateAndStore(ctx, "user-2", "other-meta")
+	if !errors.Is(err, sessions.ErrSessionCollision) {
+		t.Fatalf("expected ErrSessionCollision on a re-used session Id, got %v", err)
+	}
+}
+
+func TestStore_GenerateAndStore_ReusesIdOfExpiredSession(t *testing.T) {
+	ctx := context.Background()
+	store := New(&fixedGenerator{sessionIds: [][]byte{[]byte("session-1")}})
+
+	if _, err := store.GenerateAndStoreWithTTL(ctx, "user-1", "meta", time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	session, err := store.GenerateAndStore(ctx, "user-2", "new-meta")
+	if err != nil {
+		t.Fatalf("expected the expired session's Id to be reusable, got error: %v", err)
+	}
+
+	userId, metaData, _, err := store.Get(ctx, session)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if userId != "user-2" || metaData != "new-meta" {
+		t.Fatalf("expected the re-used session to hold the new record, got userId=%q metaData=%q", userId, metaData)
+	}
+	if !bytes.Equal(session, []byte("session-1")) {
+		t.Fatalf("expected session Id to be reused, got %q", session)
+	}
+}
+
+func TestStore_DeleteAllForUser(t *testing.T) {
+	ctx := context.Background()
+	store := New(&fixedGenerator{sessionIds: [][]byte{[]byte("session-1"), []byte("session-2"), []byte("session-3")}})
+
+	session1, _ := store.GenerateAndStore(ctx, "user-1", "a")
+	_, _ = store.GenerateAndStore(ctx, "user-1", "b")
+	session3, _ := store.GenerateAndStore(ctx, "user-2", "c")
+
+	deleted, err := store.DeleteAllForUser(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != 2 {
+		t.Fatalf("expected 2 sessions deleted for user-1, got %d", deleted)
+	}
+
+	if _, _, _, err := store.Get(ctx, session1); !errors.Is(err, sessions.ErrSessionNotFound) {
+		t.Fatalf("expected user-1's session to be gone, got %v", err)
+	}
+	if _, _, _, err := store.Get(ctx, session3); err != nil {
+		t.Fatalf("expected user-2's session to survive, got %v", err)
+	}
+}
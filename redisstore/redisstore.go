@@ -0,0 +1,176 @@
+// Package redisstore is a SessionStorer backed by Redis, using go-redis/redis.
+package redisstore
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	sessions "github.com/wojnosystems/go_session_store"
+)
+
+// DefaultKeyPrefix is used when Config.KeyPrefix is empty.
+const DefaultKeyPrefix = "sess:"
+
+// fieldSeparator joins the userId and metaData inside a single Redis string value.
+// decodeValue splits on its first occurrence, so metaData may contain it, but a userId that
+// contains it is split at the wrong place.
+const fieldSeparator = "\x00"
+
+// Config configures a Store.
+type Config struct {
+	// Client is the go-redis client to store sessions through.
+	Client *redis.Client
+	// Generator creates new session Ids.
+	Generator sessions.SessionIdGenerator
+	// KeyPrefix is prepended to every session Id to form the Redis key. Defaults to DefaultKeyPrefix.
+	KeyPrefix string
+	// TTL is used by GenerateAndStore, which has no ttl parameter of its own. A TTL of 0 means sessions never expire.
+	TTL time.Duration
+}
+
+// Store is a SessionStorer backed by Redis.
+type Store struct {
+	client    *redis.Client
+	generator sessions.SessionIdGenerator
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// New creates a Store from cfg.
+func New(cfg Config) *Store {
+	keyPrefix := cfg.KeyPrefix
+	if keyPrefix == "" {
+		keyPrefix = DefaultKeyPrefix
+	}
+	return &Store{
+		client:    cfg.Client,
+		generator: cfg.Generator,
+		keyPrefix: keyPrefix,
+		ttl:       cfg.TTL,
+	}
+}
+
+func (s *Store) key(session []byte) string {
+	return s.keyPrefix + string(session)
+}
+
+func encodeValue(userId string, metaData string) string {
+	return userId + fieldSeparator + metaData
+}
+
+func decodeValue(value string) (userId string, metaData string) {
+	userId, metaData, _ = strings.Cut(value, fieldSeparator)
+	return userId, metaData
+}
+
+func (s *Store) GenerateAndStore(ctx context.Context, userId string, metaData string) (session []byte, err error) {
+	return s.GenerateAndStoreWithTTL(ctx, userId, metaData, s.ttl)
+}
+
+func (s *Store) GenerateAndStoreWithTTL(ctx context.Context, userId string, metaData string, ttl time.Duration) (session []byte, err error) {
+	session, err = s.generator.Generate()
+	if err != nil {
+		return nil, err
+	}
+	// A ttl <= 0 means "never expires"; go-redis sends a zero expiration as a plain SETNX.
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	// SET NX doubles as our collision check: it only writes if the key is not already present.
+	ok, err := s.client.SetNX(ctx, s.key(session), encodeValue(userId, metaData), ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, sessions.ErrSessionCollision
+	}
+	return session, nil
+}
+
+func (s *Store) Get(ctx context.Context, session []byte) (userId string, metaData string, expiresAt time.Time, err error) {
+	key := s.key(session)
+	value, err := s.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", "", time.Time{}, sessions.ErrSessionNotFound
+	}
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	userId, metaData = decodeValue(value)
+
+	ttl, err := s.client.TTL(ctx, key).Result()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	return userId, metaData, expiresAt, nil
+}
+
+// Update replaces the metaData stored against session, leaving userId and TTL untouched,
+// implementing sessions.UpdatableStorer. The read-modify-write is wrapped in a WATCH
+// transaction so a concurrent Delete/expiry between the read and write aborts the update
+// instead of resurrecting the session with stale data.
+func (s *Store) Update(ctx context.Context, session []byte, metaData string) error {
+	key := s.key(session)
+	return s.client.Watch(ctx, func(tx *redis.Tx) error {
+		value, err := tx.Get(ctx, key).Result()
+		if err == redis.Nil {
+			return sessions.ErrSessionNotFound
+		}
+		if err != nil {
+			return err
+		}
+		userId, _ := decodeValue(value)
+
+		ttl, err := tx.TTL(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		if ttl < 0 {
+			ttl = 0
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, encodeValue(userId, metaData), ttl)
+			return nil
+		})
+		return err
+	}, key)
+}
+
+func (s *Store) Delete(ctx context.Context, session []byte) error {
+	return s.client.Del(ctx, s.key(session)).Err()
+}
+
+func (s *Store) DeleteAllForUser(ctx context.Context, userId string) (deleted int, err error) {
+	var cursor uint64
+	for {
+		keys, next, err := s.client.Scan(ctx, cursor, s.keyPrefix+"*", 100).Result()
+		if err != nil {
+			return deleted, err
+		}
+		for _, key := range keys {
+			value, err := s.client.Get(ctx, key).Result()
+			if err != nil {
+				continue
+			}
+			uid, _ := decodeValue(value)
+			if uid != userId {
+				continue
+			}
+			if err := s.client.Del(ctx, key).Err(); err == nil {
+				deleted++
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,61 @@
+package go_session_store
+
+import (
+	"context"
+	"time"
+)
+
+// MetadataTransform lets Regenerate update a session's metaData as part of rotation, for
+// example to bump a role or add a step-up-auth claim. Returning an error aborts the
+// regeneration and leaves oldSession untouched.
+type MetadataTransform func(old string) (newMetaData string, err error)
+
+// Regenerate rotates a session Id in place: it reads the userId/metaData/expiresAt currently
+// stored under oldSession, creates a brand new session for that userId via New (or, if
+// oldSession had a TTL, NewWithTTL for however much of it remains, so the same collision-retry
+// semantics apply either way), and deletes oldSession once the new one is safely stored. Use
+// this whenever a user's authentication state or privilege level changes (login, MFA step-up,
+// role change) to prevent session fixation, per OWASP guidance.
+// @param ctx the context to use for timeouts, if required
+// @param storer the session storage to read the old session from and write the new one to
+// @param oldSession the session to rotate away from
+// @param maxGenerateAttempts the maximum number of times to try to generate and save the new session Id before giving up, passed through to New/NewWithTTL
+// @param transform if non-nil, is called with the old metaData and its return value is stored against the new session instead of the old metaData unchanged
+// @return newSession the freshly generated session that replaces oldSession
+// @return err errors encountered reading oldSession, running transform, generating the new session, or deleting oldSession
+func Regenerate(ctx context.Context, storer SessionStorer, oldSession []byte, maxGenerateAttempts int, transform MetadataTransform) (newSession []byte, err error) {
+	userId, metaData, expiresAt, err := storer.Get(ctx, oldSession)
+	if err != nil {
+		return nil, err
+	}
+
+	if transform != nil {
+		metaData, err = transform(metaData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if expiresAt.IsZero() {
+		newSession, err = New(ctx, storer, userId, metaData, maxGenerateAttempts)
+	} else {
+		// GenerateAndStoreWithTTL treats ttl <= 0 as "never expires", so a remaining duration
+		// that has already rounded down to zero (or gone negative, for a session moments from
+		// expiring) must be floored at a positive value. Otherwise a session on the verge of
+		// expiring would regenerate into one that never does.
+		remaining := time.Until(expiresAt)
+		if remaining <= 0 {
+			remaining = time.Nanosecond
+		}
+		newSession, err = NewWithTTL(ctx, storer, userId, metaData, remaining, maxGenerateAttempts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = storer.Delete(ctx, oldSession); err != nil {
+		return nil, err
+	}
+
+	return newSession, nil
+}
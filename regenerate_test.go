@@ -0,0 +1,167 @@
+package go_session_store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sessions "github.com/wojnosystems/go_session_store"
+)
+
+type fakeGenerateCall struct {
+	userId   string
+	metaData string
+	ttl      time.Duration
+}
+
+// fakeStorer is a hand-rolled sessions.SessionStorer whose Get/GenerateAndStoreWithTTL
+// behavior is fully controllable, so Regenerate's logic can be tested without depending on
+// a real backend's timing or collision behavior.
+type fakeStorer struct {
+	userId    string
+	metaData  string
+	expiresAt time.Time
+	getErr    error
+
+	collisionsBeforeSuccess int
+	generateCalls           []fakeGenerateCall
+
+	deletedSessions [][]byte
+}
+
+func (f *fakeStorer) GenerateAndStore(ctx context.Context, userId string, metaData string) ([]byte, error) {
+	return f.GenerateAndStoreWithTTL(ctx, userId, metaData, 0)
+}
+
+func (f *fakeStorer) GenerateAndStoreWithTTL(ctx context.Context, userId string, metaData string, ttl time.Duration) ([]byte, error) {
+	f.generateCalls = append(f.generateCalls, fakeGenerateCall{userId: userId, metaData: metaData, ttl: ttl})
+	if len(f.generateCalls) <= f.collisionsBeforeSuccess {
+		return nil, sessions.ErrSessionCollision
+	}
+	return []byte("new-session"), nil
+}
+
+func (f *fakeStorer) Get(ctx context.Context, session []byte) (userId string, metaData string, expiresAt time.Time, err error) {
+	return f.userId, f.metaData, f.expiresAt, f.getErr
+}
+
+func (f *fakeStorer) Delete(ctx context.Context, session []byte) error {
+	f.deletedSessions = append(f.deletedSessions, session)
+	return nil
+}
+
+func (f *fakeStorer) DeleteAllForUser(ctx context.Context, userId string) (int, error) {
+	return 0, nil
+}
+
+func TestRegenerate_CarriesForwardRemainingTTL(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "meta", expiresAt: time.Now().Add(time.Hour)}
+
+	newSession, err := sessions.Regenerate(context.Background(), storer, []byte("old-session"), 3, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(newSession) != "new-session" {
+		t.Fatalf("expected the new session from GenerateAndStoreWithTTL, got %q", newSession)
+	}
+
+	if len(storer.generateCalls) != 1 {
+		t.Fatalf("expected exactly one GenerateAndStoreWithTTL call, got %d", len(storer.generateCalls))
+	}
+	ttl := storer.generateCalls[0].ttl
+	if ttl <= 0 || ttl > time.Hour {
+		t.Fatalf("expected a carried-forward ttl in (0, 1h], got %v", ttl)
+	}
+}
+
+func TestRegenerate_NearExpiryTTLStaysPositive(t *testing.T) {
+	// expiresAt is already in the past: time.Until(expiresAt) will be <= 0, which would
+	// otherwise be (mis)read by GenerateAndStoreWithTTL as "never expires".
+	storer := &fakeStorer{userId: "user-1", metaData: "meta", expiresAt: time.Now().Add(-time.Minute)}
+
+	if _, err := sessions.Regenerate(context.Background(), storer, []byte("old-session"), 3, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(storer.generateCalls) != 1 {
+		t.Fatalf("expected exactly one GenerateAndStoreWithTTL call, got %d", len(storer.generateCalls))
+	}
+	if ttl := storer.generateCalls[0].ttl; ttl <= 0 {
+		t.Fatalf("expected a positive ttl for a session on the verge of expiring, got %v (would mean 'never expires')", ttl)
+	}
+}
+
+func TestRegenerate_NoExpiryUsesUnboundedNew(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "meta"}
+
+	if _, err := sessions.Regenerate(context.Background(), storer, []byte("old-session"), 3, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(storer.generateCalls) != 1 {
+		t.Fatalf("expected exactly one generate call, got %d", len(storer.generateCalls))
+	}
+	if ttl := storer.generateCalls[0].ttl; ttl != 0 {
+		t.Fatalf("expected ttl 0 (never expires) for a session with no expiresAt, got %v", ttl)
+	}
+}
+
+func TestRegenerate_TransformErrorLeavesOldSessionUntouched(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "meta"}
+	transformErr := errors.New("boom")
+	transform := func(old string) (string, error) { return "", transformErr }
+
+	_, err := sessions.Regenerate(context.Background(), storer, []byte("old-session"), 3, transform)
+	if !errors.Is(err, transformErr) {
+		t.Fatalf("expected the transform's error to propagate, got %v", err)
+	}
+	if len(storer.generateCalls) != 0 {
+		t.Fatalf("expected no session to be generated when transform fails, got %d calls", len(storer.generateCalls))
+	}
+	if len(storer.deletedSessions) != 0 {
+		t.Fatalf("expected oldSession to be left untouched when transform fails, but Delete was called")
+	}
+}
+
+func TestRegenerate_TransformUpdatesMetaData(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "role=user"}
+	transform := func(old string) (string, error) { return "role=admin", nil }
+
+	if _, err := sessions.Regenerate(context.Background(), storer, []byte("old-session"), 3, transform); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(storer.generateCalls) != 1 || storer.generateCalls[0].metaData != "role=admin" {
+		t.Fatalf("expected the new session to be stored with the transformed metaData, got %+v", storer.generateCalls)
+	}
+}
+
+func TestRegenerate_CollisionRetryPassesThroughToNew(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "meta", collisionsBeforeSuccess: 2}
+
+	newSession, err := sessions.Regenerate(context.Background(), storer, []byte("old-session"), 5, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(newSession) != "new-session" {
+		t.Fatalf("expected Regenerate to return the session produced after retrying, got %q", newSession)
+	}
+	if len(storer.generateCalls) != 3 {
+		t.Fatalf("expected 2 collisions then 1 success (3 calls), got %d", len(storer.generateCalls))
+	}
+	if len(storer.deletedSessions) != 1 || string(storer.deletedSessions[0]) != "old-session" {
+		t.Fatalf("expected oldSession to be deleted exactly once after a successful retry, got %v", storer.deletedSessions)
+	}
+}
+
+func TestRegenerate_ExhaustedRetriesReturnsCollisionAndLeavesOldSession(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "meta", collisionsBeforeSuccess: 10}
+
+	_, err := sessions.Regenerate(context.Background(), storer, []byte("old-session"), 3, nil)
+	if !errors.Is(err, sessions.ErrSessionCollision) {
+		t.Fatalf("expected ErrSessionCollision once maxGenerateAttempts is exhausted, got %v", err)
+	}
+	if len(storer.deletedSessions) != 0 {
+		t.Fatalf("expected oldSession to be left untouched when regeneration never succeeds, but Delete was called")
+	}
+}
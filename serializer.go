@@ -0,0 +1,52 @@
+package go_session_store
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// SessionSerializer converts typed metadata to and from the string representation that
+// SessionStorer implementations persist. It lets callers store structured claims (roles,
+// IP, user-agent, issued-at, ...) instead of hand-rolling their own encoding. See TypedStore.
+type SessionSerializer interface {
+	// Marshal encodes v into bytes suitable for storing as a session's metaData.
+	// @param v the value to encode. Implementations may require v to be a pointer or a particular kind, consistent with the underlying encoding package
+	// @return data the encoded bytes
+	// @return err errors encountered while encoding v
+	Marshal(v any) (data []byte, err error)
+
+	// Unmarshal decodes data, previously produced by Marshal, into v.
+	// @param data the bytes to decode, as produced by Marshal
+	// @param v a pointer to the value to decode into
+	// @return err errors encountered while decoding data
+	Unmarshal(data []byte, v any) (err error)
+}
+
+// JSONSerializer is a SessionSerializer backed by encoding/json.
+type JSONSerializer struct{}
+
+func (JSONSerializer) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONSerializer) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// GobSerializer is a SessionSerializer backed by encoding/gob. Values encoded with it must
+// be registered with gob.Register if they are stored behind an interface, as with any other
+// use of the gob package.
+type GobSerializer struct{}
+
+func (GobSerializer) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobSerializer) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
@@ -0,0 +1,56 @@
+package go_session_store_test
+
+import (
+	"testing"
+
+	sessions "github.com/wojnosystems/go_session_store"
+)
+
+type claims struct {
+	Role      string
+	IssuedRaw int64
+}
+
+func TestJSONSerializer_RoundTrip(t *testing.T) {
+	serializer := sessions.JSONSerializer{}
+	original := claims{Role: "admin", IssuedRaw: 12345}
+
+	data, err := serializer.Marshal(original)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling: %v", err)
+	}
+
+	var decoded claims
+	if err := serializer.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error unmarshaling: %v", err)
+	}
+	if decoded != original {
+		t.Fatalf("expected round-tripped value %+v, got %+v", original, decoded)
+	}
+}
+
+func TestGobSerializer_RoundTrip(t *testing.T) {
+	serializer := sessions.GobSerializer{}
+	original := claims{Role: "user", IssuedRaw: 67890}
+
+	data, err := serializer.Marshal(original)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling: %v", err)
+	}
+
+	var decoded claims
+	if err := serializer.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error unmarshaling: %v", err)
+	}
+	if decoded != original {
+		t.Fatalf("expected round-tripped value %+v, got %+v", original, decoded)
+	}
+}
+
+func TestJSONSerializer_UnmarshalRejectsGarbage(t *testing.T) {
+	serializer := sessions.JSONSerializer{}
+	var decoded claims
+	if err := serializer.Unmarshal([]byte("not json"), &decoded); err == nil {
+		t.Fatal("expected an error unmarshaling non-JSON data")
+	}
+}
@@ -0,0 +1,135 @@
+// Package sqlstore is a SessionStorer backed by database/sql, against the schema
+// documented by the createTableSQL constant in this file (see CreateSchema).
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	sessions "github.com/wojnosystems/go_session_store"
+)
+
+// createTableSQL is the schema CreateSchema applies. Column types are written generically and
+// placeholders throughout this file use the `?` style, so this only works as-is against
+// drivers that accept that (e.g. sqlite3). mysql accepts `?` placeholders but rejects a BLOB
+// primary key without a key length, so CreateSchema fails there and the table has to be
+// created by a migration of its own. Drivers with a different placeholder
+// syntax (e.g. lib/pq and pgx, which require $1/$2/...) or that need different column types
+// (e.g. BYTEA instead of BLOB) are not compatible with this Store and should run their own
+// migration against this shape and implement SessionStorer directly instead.
+const createTableSQL = `CREATE TABLE IF NOT EXISTS sessions (
+	id BLOB PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	metadata BLOB,
+	expires_at TIMESTAMP
+)`
+
+// Store is a SessionStorer backed by database/sql.
+type Store struct {
+	db        *sql.DB
+	generator sessions.SessionIdGenerator
+}
+
+// New creates a Store that reads and writes through db, using generator to create new session Ids.
+// Callers are responsible for calling CreateSchema (or applying an equivalent migration) before use.
+func New(db *sql.DB, generator sessions.SessionIdGenerator) *Store {
+	return &Store{db: db, generator: generator}
+}
+
+// CreateSchema creates the sessions table described by createTableSQL if it does not already exist.
+func (s *Store) CreateSchema(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, createTableSQL)
+	return err
+}
+
+func (s *Store) GenerateAndStore(ctx context.Context, userId string, metaData string) (session []byte, err error) {
+	return s.GenerateAndStoreWithTTL(ctx, userId, metaData, 0)
+}
+
+func (s *Store) GenerateAndStoreWithTTL(ctx context.Context, userId string, metaData string, ttl time.Duration) (session []byte, err error) {
+	session, err = s.generator.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt sql.NullTime
+	if ttl > 0 {
+		expiresAt = sql.NullTime{Time: time.Now().Add(ttl), Valid: true}
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO sessions (id, user_id, metadata, expires_at) VALUES (?, ?, ?, ?)`,
+		session, userId, metaData, expiresAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, sessions.ErrSessionCollision
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *Store) Get(ctx context.Context, session []byte) (userId string, metaData string, expiresAt time.Time, err error) {
+	var expires sql.NullTime
+	row := s.db.QueryRowContext(ctx,
+		`SELECT user_id, metadata, expires_at FROM sessions WHERE id = ?`, session)
+	if err = row.Scan(&userId, &metaData, &expires); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", time.Time{}, sessions.ErrSessionNotFound
+		}
+		return "", "", time.Time{}, err
+	}
+
+	if expires.Valid {
+		if time.Now().After(expires.Time) {
+			_, _ = s.db.ExecContext(ctx, `DELETE FROM sessions WHERE id = ?`, session)
+			return "", "", time.Time{}, sessions.ErrSessionNotFound
+		}
+		expiresAt = expires.Time
+	}
+	return userId, metaData, expiresAt, nil
+}
+
+// Update replaces the metaData stored against session, leaving user_id and expires_at
+// untouched, implementing sessions.UpdatableStorer.
+func (s *Store) Update(ctx context.Context, session []byte, metaData string) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE sessions SET metadata = ? WHERE id = ?`, metaData, session)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sessions.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, session []byte) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE id = ?`, session)
+	return err
+}
+
+func (s *Store) DeleteAllForUser(ctx context.Context, userId string) (deleted int, err error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = ?`, userId)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// isUniqueViolation recognizes the unique-constraint-violation error messages of the
+// `?`-placeholder database/sql drivers this package targets (sqlite3, mysql).
+// There is no portable error type for this across drivers, so we match on message text.
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") ||
+		strings.Contains(msg, "duplicate key value") ||
+		strings.Contains(msg, "Duplicate entry")
+}
@@ -0,0 +1,60 @@
+package go_session_store
+
+import (
+	"context"
+	"encoding/base64"
+)
+
+// TypedStore wraps a SessionStorer so callers can work with a typed metadata value T
+// instead of a plain string. The underlying SessionStorer remains the storage layer;
+// TypedStore only serializes T to/from the string metaData it stores there.
+type TypedStore[T any] struct {
+	storer     SessionStorer
+	serializer SessionSerializer
+}
+
+// NewTypedStore wraps storer so that GenerateAndStore and Get work in terms of T instead
+// of a plain metaData string, using serializer to convert between the two.
+// @param storer the underlying session storage
+// @param serializer used to Marshal T before writing and Unmarshal it after reading
+func NewTypedStore[T any](storer SessionStorer, serializer SessionSerializer) *TypedStore[T] {
+	return &TypedStore[T]{storer: storer, serializer: serializer}
+}
+
+// GenerateAndStore serializes meta and stores it under a new session for userId.
+// @param ctx the context to use for timeouts, if required
+// @param userId the user that this session will represent
+// @param meta the typed metadata to serialize and store with the session
+// @return session identifier returned by the underlying SessionStorer
+// @return err errors encountered while serializing meta or storing the session
+func (t *TypedStore[T]) GenerateAndStore(ctx context.Context, userId string, meta T) (session []byte, err error) {
+	data, err := t.serializer.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	return t.storer.GenerateAndStore(ctx, userId, base64.StdEncoding.EncodeToString(data))
+}
+
+// Get looks up session and deserializes its stored metaData into a T.
+// @param ctx the context to use for timeouts, if required
+// @param session the session that was created by GenerateAndStore
+// @return userId the user that this session represents
+// @return meta the deserialized metadata. The zero value of T if the session does not exist or metaData could not be decoded
+// @return err the error encountered looking up or deserializing the session, which may be ErrSessionNotFound
+func (t *TypedStore[T]) Get(ctx context.Context, session []byte) (userId string, meta T, err error) {
+	userId, encoded, _, err := t.storer.Get(ctx, session)
+	if err != nil {
+		return "", meta, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", meta, err
+	}
+
+	if err = t.serializer.Unmarshal(data, &meta); err != nil {
+		var zero T
+		return "", zero, err
+	}
+	return userId, meta, nil
+}
@@ -0,0 +1,55 @@
+package go_session_store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sessions "github.com/wojnosystems/go_session_store"
+)
+
+func TestTypedStore_GenerateAndStoreThenGet_RoundTrips(t *testing.T) {
+	storer := &fakeStorer{}
+	typed := sessions.NewTypedStore[claims](storer, sessions.JSONSerializer{})
+
+	session, err := typed.GenerateAndStore(context.Background(), "user-1", claims{Role: "admin", IssuedRaw: 42})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(session) != "new-session" {
+		t.Fatalf("expected the underlying storer's session, got %q", session)
+	}
+
+	// GenerateAndStore went through the fake storer's GenerateAndStoreWithTTL, which doesn't
+	// persist anything itself, so point Get at what it would have stored.
+	storer.userId = "user-1"
+	storer.metaData = storer.generateCalls[0].metaData
+
+	userId, meta, err := typed.Get(context.Background(), session)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if userId != "user-1" || meta != (claims{Role: "admin", IssuedRaw: 42}) {
+		t.Fatalf("expected round-tripped (userId, meta) = (user-1, {admin 42}), got (%q, %+v)", userId, meta)
+	}
+}
+
+func TestTypedStore_Get_PropagatesErrSessionNotFound(t *testing.T) {
+	storer := &fakeStorer{getErr: sessions.ErrSessionNotFound}
+	typed := sessions.NewTypedStore[claims](storer, sessions.JSONSerializer{})
+
+	_, _, err := typed.Get(context.Background(), []byte("missing-session"))
+	if !errors.Is(err, sessions.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound to propagate, got %v", err)
+	}
+}
+
+func TestTypedStore_Get_ReturnsErrorOnUndecodableMetaData(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "not valid base64!!"}
+	typed := sessions.NewTypedStore[claims](storer, sessions.JSONSerializer{})
+
+	_, _, err := typed.Get(context.Background(), []byte("some-session"))
+	if err == nil {
+		t.Fatal("expected an error decoding metaData that isn't valid base64")
+	}
+}
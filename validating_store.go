@@ -0,0 +1,116 @@
+package go_session_store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Action is what a SessionValidator decides should happen to a session it inspected.
+type Action int
+
+const (
+	// Accept leaves the session as-is.
+	Accept Action = iota
+	// Rotate replaces the session with a freshly generated one via Regenerate, keeping the
+	// same userId/metaData.
+	Rotate
+	// Revoke deletes the session outright.
+	Revoke
+)
+
+// ValidationRequest carries the request-specific details a SessionValidator checks a
+// session's stored metaData against, such as the caller's current IP address and user agent.
+type ValidationRequest struct {
+	IP        string
+	UserAgent string
+}
+
+// SessionValidator inspects a session being looked up and decides whether it still looks
+// legitimate, to defend against session re-use/hijacking (OWASP anomaly-detection guidance).
+type SessionValidator interface {
+	// Validate inspects storedMeta (the session's metaData) against req (the current
+	// request's details) and decides what ValidatingStore.Get should do about it.
+	// @param ctx the context to use for timeouts, if required
+	// @param session the session being looked up
+	// @param storedMeta the metaData stored against session
+	// @param req the details of the request the session is being used for
+	// @return action what ValidatingStore.Get should do with the session
+	// @return err errors encountered while validating, which abort the lookup
+	Validate(ctx context.Context, session []byte, storedMeta string, req ValidationRequest) (action Action, err error)
+}
+
+// ErrSessionRevoked is returned by ValidatingStore.Get when a SessionValidator returned Revoke.
+var ErrSessionRevoked = errors.New("session was revoked by a session validator")
+
+// ValidatingStore decorates a SessionStorer's Get with a chain of SessionValidators, so
+// callers can layer defensive checks (IP change, user-agent change, max absolute age, ...)
+// without changing the core SessionStorer interface.
+type ValidatingStore struct {
+	storer              SessionStorer
+	validators          []SessionValidator
+	maxGenerateAttempts int
+}
+
+// NewValidatingStore wraps storer so that Get runs each validator, in order, against the
+// looked-up session. maxGenerateAttempts is forwarded to Regenerate when a validator returns Rotate.
+func NewValidatingStore(storer SessionStorer, maxGenerateAttempts int, validators ...SessionValidator) *ValidatingStore {
+	return &ValidatingStore{storer: storer, validators: validators, maxGenerateAttempts: maxGenerateAttempts}
+}
+
+func (v *ValidatingStore) GenerateAndStore(ctx context.Context, userId string, metaData string) (session []byte, err error) {
+	return v.storer.GenerateAndStore(ctx, userId, metaData)
+}
+
+func (v *ValidatingStore) GenerateAndStoreWithTTL(ctx context.Context, userId string, metaData string, ttl time.Duration) (session []byte, err error) {
+	return v.storer.GenerateAndStoreWithTTL(ctx, userId, metaData, ttl)
+}
+
+func (v *ValidatingStore) Delete(ctx context.Context, session []byte) error {
+	return v.storer.Delete(ctx, session)
+}
+
+func (v *ValidatingStore) DeleteAllForUser(ctx context.Context, userId string) (deleted int, err error) {
+	return v.storer.DeleteAllForUser(ctx, userId)
+}
+
+// Get looks up session through the underlying SessionStorer and then runs req past each
+// configured SessionValidator in order, stopping at the first one that returns something other
+// than Accept. If that is Revoke, the session is deleted and Get returns ErrSessionRevoked. If
+// it is Rotate, Get calls Regenerate and returns the replacement session as newSession; callers
+// must start using newSession in place of session from then on.
+// @param ctx the context to use for timeouts, if required
+// @param session the session that was created by GenerateAndStore
+// @param req the details of the request the session is being used for, passed to each validator
+// @return userId the user that this session represents
+// @return metaData the metaData stored against session
+// @return expiresAt the session's expiry, as returned by the underlying SessionStorer
+// @return newSession non-nil only when a validator returned Rotate, holding the session's replacement
+// @return err the error encountered looking up or validating the session, which may be ErrSessionNotFound or ErrSessionRevoked
+func (v *ValidatingStore) Get(ctx context.Context, session []byte, req ValidationRequest) (userId string, metaData string, expiresAt time.Time, newSession []byte, err error) {
+	userId, metaData, expiresAt, err = v.storer.Get(ctx, session)
+	if err != nil {
+		return "", "", time.Time{}, nil, err
+	}
+
+	for _, validator := range v.validators {
+		action, verr := validator.Validate(ctx, session, metaData, req)
+		if verr != nil {
+			return "", "", time.Time{}, nil, verr
+		}
+
+		switch action {
+		case Revoke:
+			_ = v.storer.Delete(ctx, session)
+			return "", "", time.Time{}, nil, ErrSessionRevoked
+		case Rotate:
+			newSession, err = Regenerate(ctx, v.storer, session, v.maxGenerateAttempts, nil)
+			if err != nil {
+				return "", "", time.Time{}, nil, err
+			}
+			return userId, metaData, expiresAt, newSession, nil
+		}
+	}
+
+	return userId, metaData, expiresAt, nil, nil
+}
@@ -0,0 +1,114 @@
+package go_session_store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sessions "github.com/wojnosystems/go_session_store"
+)
+
+// fakeValidator is a sessions.SessionValidator whose decision is fixed in advance, so each
+// branch of ValidatingStore.Get can be exercised independently.
+type fakeValidator struct {
+	action sessions.Action
+	err    error
+	calls  []sessions.ValidationRequest
+}
+
+func (f *fakeValidator) Validate(ctx context.Context, session []byte, storedMeta string, req sessions.ValidationRequest) (sessions.Action, error) {
+	f.calls = append(f.calls, req)
+	return f.action, f.err
+}
+
+func TestValidatingStore_Get_AcceptReturnsUnderlyingSession(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "meta"}
+	validator := &fakeValidator{action: sessions.Accept}
+	vs := sessions.NewValidatingStore(storer, 3, validator)
+
+	userId, metaData, _, newSession, err := vs.Get(context.Background(), []byte("session-1"), sessions.ValidationRequest{IP: "1.2.3.4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if userId != "user-1" || metaData != "meta" {
+		t.Fatalf("expected the underlying (userId, metaData) to pass through unchanged, got (%q, %q)", userId, metaData)
+	}
+	if newSession != nil {
+		t.Fatalf("expected no newSession on Accept, got %q", newSession)
+	}
+	if len(validator.calls) != 1 || validator.calls[0].IP != "1.2.3.4" {
+		t.Fatalf("expected the validator to be called once with the request, got %+v", validator.calls)
+	}
+}
+
+func TestValidatingStore_Get_RevokeDeletesAndReturnsErrSessionRevoked(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "meta"}
+	validator := &fakeValidator{action: sessions.Revoke}
+	vs := sessions.NewValidatingStore(storer, 3, validator)
+
+	_, _, _, newSession, err := vs.Get(context.Background(), []byte("session-1"), sessions.ValidationRequest{})
+	if !errors.Is(err, sessions.ErrSessionRevoked) {
+		t.Fatalf("expected ErrSessionRevoked, got %v", err)
+	}
+	if newSession != nil {
+		t.Fatalf("expected no newSession on Revoke, got %q", newSession)
+	}
+	if len(storer.deletedSessions) != 1 || string(storer.deletedSessions[0]) != "session-1" {
+		t.Fatalf("expected the session to be deleted exactly once, got %v", storer.deletedSessions)
+	}
+}
+
+func TestValidatingStore_Get_RotateRegeneratesAndReturnsNewSession(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "meta"}
+	validator := &fakeValidator{action: sessions.Rotate}
+	vs := sessions.NewValidatingStore(storer, 3, validator)
+
+	userId, metaData, _, newSession, err := vs.Get(context.Background(), []byte("session-1"), sessions.ValidationRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if userId != "user-1" || metaData != "meta" {
+		t.Fatalf("expected (userId, metaData) of the rotated session to be returned, got (%q, %q)", userId, metaData)
+	}
+	if string(newSession) != "new-session" {
+		t.Fatalf("expected newSession to be the session Regenerate produced, got %q", newSession)
+	}
+	if len(storer.generateCalls) != 1 {
+		t.Fatalf("expected Regenerate to have generated exactly one replacement session, got %d", len(storer.generateCalls))
+	}
+	if len(storer.deletedSessions) != 1 || string(storer.deletedSessions[0]) != "session-1" {
+		t.Fatalf("expected the old session to be deleted by Regenerate, got %v", storer.deletedSessions)
+	}
+}
+
+func TestValidatingStore_Get_ValidatorErrorAbortsWithoutMutatingSession(t *testing.T) {
+	storer := &fakeStorer{userId: "user-1", metaData: "meta"}
+	validatorErr := errors.New("boom")
+	validator := &fakeValidator{err: validatorErr}
+	vs := sessions.NewValidatingStore(storer, 3, validator)
+
+	_, _, _, newSession, err := vs.Get(context.Background(), []byte("session-1"), sessions.ValidationRequest{})
+	if !errors.Is(err, validatorErr) {
+		t.Fatalf("expected the validator's error to propagate, got %v", err)
+	}
+	if newSession != nil {
+		t.Fatalf("expected no newSession when a validator errors, got %q", newSession)
+	}
+	if len(storer.deletedSessions) != 0 || len(storer.generateCalls) != 0 {
+		t.Fatalf("expected no mutation of the session when a validator errors, got deleted=%v generated=%v", storer.deletedSessions, storer.generateCalls)
+	}
+}
+
+func TestValidatingStore_Get_PropagatesUnderlyingGetError(t *testing.T) {
+	storer := &fakeStorer{getErr: sessions.ErrSessionNotFound}
+	validator := &fakeValidator{action: sessions.Accept}
+	vs := sessions.NewValidatingStore(storer, 3, validator)
+
+	_, _, _, _, err := vs.Get(context.Background(), []byte("missing-session"), sessions.ValidationRequest{})
+	if !errors.Is(err, sessions.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound to propagate, got %v", err)
+	}
+	if len(validator.calls) != 0 {
+		t.Fatalf("expected validators not to run when the underlying Get fails, got %d calls", len(validator.calls))
+	}
+}
@@ -0,0 +1,93 @@
+package go_session_store
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// IPPrefixValidator flags a session when the request's IP address no longer shares the
+// same network prefix as the IP it was issued under. Since metaData is a plain string,
+// callers supply IssuedIP to extract/parse the originally-recorded IP from it.
+type IPPrefixValidator struct {
+	// IssuedIP extracts the IP the session was issued under from its stored metaData.
+	IssuedIP func(storedMeta string) (net.IP, error)
+	// PrefixBits is how many leading bits of the address must still match, e.g. 24 for a typical IPv4 /24.
+	PrefixBits int
+	// OnMismatch is returned when the prefixes differ. Typically Rotate (re-issue under the new IP) or Revoke.
+	OnMismatch Action
+}
+
+func (val *IPPrefixValidator) Validate(ctx context.Context, session []byte, storedMeta string, req ValidationRequest) (Action, error) {
+	issuedIP, err := val.IssuedIP(storedMeta)
+	if err != nil {
+		return Accept, err
+	}
+
+	currentIP := net.ParseIP(req.IP)
+	if issuedIP == nil || currentIP == nil {
+		return val.OnMismatch, nil
+	}
+
+	// net.ParseIP always returns a 16-byte slice, even for IPv4 addresses, so an IPv4 address
+	// must be narrowed back to its 4-byte form before computing a bit-count mask against it.
+	// Otherwise PrefixBits is measured against the constant ::ffff:0:0 prefix instead of the
+	// address itself, and the mask matches any two IPv4 addresses.
+	if v4 := issuedIP.To4(); v4 != nil {
+		issuedIP = v4
+	}
+	if v4 := currentIP.To4(); v4 != nil {
+		currentIP = v4
+	}
+	if len(issuedIP) != len(currentIP) {
+		return val.OnMismatch, nil
+	}
+
+	mask := net.CIDRMask(val.PrefixBits, len(issuedIP)*8)
+	if !issuedIP.Mask(mask).Equal(currentIP.Mask(mask)) {
+		return val.OnMismatch, nil
+	}
+	return Accept, nil
+}
+
+// UserAgentValidator flags a session when the request's user agent no longer matches the
+// one it was issued under.
+type UserAgentValidator struct {
+	// IssuedUserAgent extracts the user agent the session was issued under from its stored metaData.
+	IssuedUserAgent func(storedMeta string) (string, error)
+	// OnMismatch is returned when the user agents differ. Typically Revoke, since a changed user agent is a strong hijacking signal.
+	OnMismatch Action
+}
+
+func (val *UserAgentValidator) Validate(ctx context.Context, session []byte, storedMeta string, req ValidationRequest) (Action, error) {
+	issuedUserAgent, err := val.IssuedUserAgent(storedMeta)
+	if err != nil {
+		return Accept, err
+	}
+	if issuedUserAgent != req.UserAgent {
+		return val.OnMismatch, nil
+	}
+	return Accept, nil
+}
+
+// MaxAbsoluteAgeValidator flags a session once it has existed for longer than MaxAge,
+// regardless of activity, bounding how long a stolen session stays usable.
+type MaxAbsoluteAgeValidator struct {
+	// IssuedAt extracts the time the session was issued from its stored metaData.
+	IssuedAt func(storedMeta string) (time.Time, error)
+	// MaxAge is the longest a session may be used for before it is flagged.
+	MaxAge time.Duration
+	// OnExceeded is returned once MaxAge has elapsed. Typically Revoke, forcing re-authentication.
+	OnExceeded Action
+}
+
+func (val *MaxAbsoluteAgeValidator) Validate(ctx context.Context, session []byte, storedMeta string, req ValidationRequest) (Action, error) {
+	issuedAt, err := val.IssuedAt(storedMeta)
+	if err != nil {
+		return Accept, err
+	}
+	if time.Since(issuedAt) > val.MaxAge {
+		return val.OnExceeded, nil
+	}
+	return Accept, nil
+}
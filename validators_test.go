@@ -0,0 +1,55 @@
+package go_session_store
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+func TestIPPrefixValidator_FlagsDifferentIPv4Slash24(t *testing.T) {
+	val := &IPPrefixValidator{
+		IssuedIP:   func(storedMeta string) (net.IP, error) { return net.ParseIP("192.168.1.5"), nil },
+		PrefixBits: 24,
+		OnMismatch: Revoke,
+	}
+
+	action, err := val.Validate(context.Background(), nil, "", ValidationRequest{IP: "10.0.0.9"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if action != Revoke {
+		t.Fatalf("expected Revoke for IPs in different /24s, got %v", action)
+	}
+}
+
+func TestIPPrefixValidator_AcceptsSameIPv4Slash24(t *testing.T) {
+	val := &IPPrefixValidator{
+		IssuedIP:   func(storedMeta string) (net.IP, error) { return net.ParseIP("192.168.1.5"), nil },
+		PrefixBits: 24,
+		OnMismatch: Revoke,
+	}
+
+	action, err := val.Validate(context.Background(), nil, "", ValidationRequest{IP: "192.168.1.200"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if action != Accept {
+		t.Fatalf("expected Accept for IPs in the same /24, got %v", action)
+	}
+}
+
+func TestIPPrefixValidator_FlagsDifferentIPv6Prefix(t *testing.T) {
+	val := &IPPrefixValidator{
+		IssuedIP:   func(storedMeta string) (net.IP, error) { return net.ParseIP("2001:db8:aaaa::1"), nil },
+		PrefixBits: 48,
+		OnMismatch: Rotate,
+	}
+
+	action, err := val.Validate(context.Background(), nil, "", ValidationRequest{IP: "2001:db8:bbbb::1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if action != Rotate {
+		t.Fatalf("expected Rotate for IPv6 addresses in different /48s, got %v", action)
+	}
+}